@@ -28,6 +28,10 @@ type FileInfo interface {
 	Type() FileType
 	GetFileInfo() os.FileInfo
 	Reader() (io.ReadCloser, error)
+	// Meta returns arbitrary metadata carried by the source that produced
+	// this FileInfo (for example, the lang/tag of the mount it came from).
+	// It is nil when the source does not attach any.
+	Meta() MountMeta
 }
 
 type DirFileInfo interface {
@@ -63,9 +67,16 @@ type AssetCompilerInterface interface {
 type TraversableInterface interface {
 	Walk(dir string, cb CbWalkFunc, mode ...WalkMode) error
 	WalkInfo(dir string, cb CbWalkInfoFunc, mode ...WalkMode) error
+	// WalkInfoC is the context-aware form of WalkInfo: a Logger attached to
+	// ctx via WithLogger sees the walk traced, including namespace, mount
+	// and parent-lookup recursion. Walk/WalkInfo have no context of their
+	// own to thread through, so they always trace through NopLogger.
+	WalkInfoC(ctx context.Context, dir string, cb CbWalkInfoFunc, mode ...WalkMode) error
 	ReadDir(dir string, cb CbWalkInfoFunc, skipDir bool) (err error)
 	Glob(pattern GlobPattern, cb func(pth string, isDir bool) error) error
 	GlobInfo(pattern GlobPattern, cb func(info FileInfo) error) error
+	// GlobInfoC is the context-aware form of GlobInfo; see WalkInfoC.
+	GlobInfoC(ctx context.Context, pattern GlobPattern, cb func(info FileInfo) error) error
 	NewGlob(pattern GlobPattern) Glob
 	NewGlobString(pattern string) Glob
 }
@@ -89,6 +100,7 @@ type Interface interface {
 	AssetCompilerInterface
 	TraversableInterface
 	LocalSourcesAttribute
+	Mounter
 	http.Handler
 	GetNameSpace(nameSpace string) (NameSpacedInterface, error)
 	NameSpaces() []NameSpacedInterface
@@ -100,6 +112,74 @@ type Interface interface {
 	Dump(cb func(info FileInfo) error, ignore ...func(pth string) bool) error
 }
 
+// Mounter grafts a source (a real filesystem path or another Interface) onto
+// a virtual path inside the assetfs tree, similar to Hugo Modules' file
+// mounts. Several mounts may target the same virtual path; single-asset
+// lookups probe them most recently registered first and the first match
+// wins, while all of them contribute for walks/globs. Single-asset lookups
+// only probe mounts whose source is a real filesystem path; a mount whose
+// source is another Interface is only seen by walks.
+type Mounter interface {
+	// Mount grafts source onto target. source is either a string holding a
+	// real filesystem path, or an Interface to mount another assetfs tree.
+	Mount(source interface{}, target string, opts ...MountOption) error
+	// Mounts returns the mounts currently registered, in registration order.
+	Mounts() []Mount
+	// MountsAt returns the mounts registered at, below or above target
+	// (a mount targeting an ancestor of target covers it too).
+	MountsAt(target string) []Mount
+}
+
+// Mount is a single source grafted onto a virtual target path.
+type Mount struct {
+	Source   interface{}
+	Target   string
+	ReadOnly bool
+	Meta     MountMeta
+	Include  GlobPattern
+	Exclude  GlobPattern
+}
+
+// MountMeta carries free-form metadata attached to a Mount (e.g. a lang/tag
+// string), surfaced on matched FileInfo via FileInfo.Meta().
+type MountMeta map[string]interface{}
+
+// MountOption configures a Mount at registration time.
+type MountOption func(m *Mount)
+
+// MountInclude only exposes files from source matching pattern.
+func MountInclude(pattern GlobPattern) MountOption {
+	return func(m *Mount) { m.Include = pattern }
+}
+
+// MountIncludeString is the string-pattern form of MountInclude.
+func MountIncludeString(pattern string) MountOption {
+	return func(m *Mount) { m.Include = NewGlobString(pattern) }
+}
+
+// MountExclude hides files from source matching pattern, even if Include
+// would otherwise allow them.
+func MountExclude(pattern GlobPattern) MountOption {
+	return func(m *Mount) { m.Exclude = pattern }
+}
+
+// MountExcludeString is the string-pattern form of MountExclude.
+func MountExcludeString(pattern string) MountOption {
+	return func(m *Mount) { m.Exclude = NewGlobString(pattern) }
+}
+
+// MountReadOnly marks the mount as read-only, rejecting writes even when the
+// underlying source is writable.
+func MountReadOnly(readOnly bool) MountOption {
+	return func(m *Mount) { m.ReadOnly = readOnly }
+}
+
+// MountWithMeta attaches lang/tag metadata to every FileInfo resolved
+// through this mount.
+func MountWithMeta(meta MountMeta) MountOption {
+	return func(m *Mount) { m.Meta = meta }
+}
+
 type PathRegistrator interface {
 	Interface
 	OnPathRegister(cb ...PathRegisterCallback)
@@ -114,4 +194,23 @@ type NameSpacedInterface interface {
 
 type RawReadGetter interface {
 	RawReader() io.ReadCloser
+}
+
+// WritableInterface extends a normally read-oriented Interface with write
+// access. It is implemented by providers backed by a writable local
+// source (see LocalSourcesGetter); providers such as bindata do not
+// implement it, and callers (e.g. assetfs/webdav) should type-assert for
+// it rather than assuming every Interface supports writes.
+type WritableInterface interface {
+	Interface
+	// Create opens path for writing, creating it if it does not exist and
+	// truncating it if it does. Intermediate directories are not created;
+	// call Mkdir first.
+	Create(path string) (io.WriteCloser, error)
+	// Mkdir creates path as a directory, including any missing parents.
+	Mkdir(path string) error
+	// Remove deletes path, which may be a file or an empty directory.
+	Remove(path string) error
+	// Rename moves oldPath to newPath, overwriting newPath if it exists.
+	Rename(oldPath, newPath string) error
 }
\ No newline at end of file
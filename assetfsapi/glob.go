@@ -0,0 +1,206 @@
+package assetfsapi
+
+import (
+	"path"
+	"strings"
+	"sync"
+)
+
+// GlobPattern describes a compiled match pattern used by the Glob/GlobInfo
+// implementations across providers (AssetFileSystem, memfs.FileSystem,
+// compose.FS). Patterns are doublestar-style: "**" is a segment matching
+// zero or more intermediate directories, "{a,b,c}" is brace alternation
+// within a segment, and a leading "!" negates the whole pattern.
+type GlobPattern interface {
+	// Dir is the longest path prefix that contains no special ("*", "?",
+	// "[", "{", "**") segment, i.e. where a walk can safely start.
+	Dir() string
+	// Match reports whether name (a single path segment, not a full
+	// path) matches this pattern's base segment.
+	Match(name string) bool
+	// AllowDirs/AllowFiles report whether directories/files should be
+	// considered at all; a pattern ending in "/" only matches dirs.
+	AllowDirs() bool
+	AllowFiles() bool
+	// IsRecursive reports whether the pattern contains a "**" segment,
+	// requiring a full subtree walk rather than a single ReadDir.
+	IsRecursive() bool
+	// Negated reports whether the pattern was written with a leading "!".
+	Negated() bool
+	// String returns the original pattern text.
+	String() string
+}
+
+// Glob is a GlobPattern usable with TraversableInterface.Glob/GlobInfo, as
+// returned by NewGlob/NewGlobString.
+type Glob interface {
+	GlobPattern
+	// CanMatchInDir reports whether any descendant of dir (a virtual
+	// directory path) could possibly satisfy this pattern, so walkers can
+	// prune subtrees that can never match (e.g. "vendor/**/*.css" skips
+	// descending into "img").
+	CanMatchInDir(dir string) bool
+}
+
+// globCache memoizes compiled patterns by their original text; Glob
+// compilation is pure, so sharing compiled patterns across calls and
+// goroutines is always safe.
+var globCache sync.Map // string -> *globPattern
+
+// NewGlob wraps an already-compiled GlobPattern as a Glob. If pattern was
+// not produced by NewGlobString, CanMatchInDir conservatively returns true.
+func NewGlob(pattern GlobPattern) Glob {
+	if g, ok := pattern.(Glob); ok {
+		return g
+	}
+	return &genericGlob{GlobPattern: pattern}
+}
+
+// NewGlobString compiles pattern into a Glob. Compiled patterns are cached
+// in a process-wide sync.Map keyed by the pattern text, so calling
+// NewGlobString repeatedly with the same string recompiles nothing after
+// the first call.
+func NewGlobString(pattern string) Glob {
+	if v, ok := globCache.Load(pattern); ok {
+		return v.(*globPattern)
+	}
+	g := compileGlob(pattern)
+	actual, _ := globCache.LoadOrStore(pattern, g)
+	return actual.(*globPattern)
+}
+
+// genericGlob adapts a hand-built GlobPattern (one not produced by
+// NewGlobString) to Glob without any pruning smarts.
+type genericGlob struct {
+	GlobPattern
+}
+
+func (g *genericGlob) CanMatchInDir(string) bool { return true }
+
+// globPattern is the doublestar-compatible Glob implementation.
+type globPattern struct {
+	raw       string
+	negated   bool
+	dirsOnly  bool
+	segments  []string // path segments, "/"-joined original, "**" kept literal
+	dir       string   // longest non-special prefix, "/"-joined
+	recursive bool
+}
+
+func compileGlob(pattern string) *globPattern {
+	raw := pattern
+	g := &globPattern{raw: raw}
+	if strings.HasPrefix(pattern, "!") {
+		g.negated = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		g.dirsOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	pattern = strings.TrimPrefix(pattern, "./")
+	g.segments = strings.Split(path.Clean("/" + pattern)[1:], "/")
+	if len(g.segments) == 1 && g.segments[0] == "" {
+		g.segments = nil
+	}
+
+	var dirSegs []string
+	for _, seg := range g.segments {
+		if isSpecialSegment(seg) {
+			break
+		}
+		dirSegs = append(dirSegs, seg)
+	}
+	for _, seg := range g.segments {
+		if seg == "**" {
+			g.recursive = true
+			break
+		}
+	}
+	g.dir = strings.Join(dirSegs, "/")
+	return g
+}
+
+func isSpecialSegment(seg string) bool {
+	return strings.ContainsAny(seg, "*?[{")
+}
+
+func (g *globPattern) String() string    { return g.raw }
+func (g *globPattern) Dir() string       { return g.dir }
+func (g *globPattern) Negated() bool     { return g.negated }
+func (g *globPattern) IsRecursive() bool { return g.recursive }
+func (g *globPattern) AllowDirs() bool   { return true }
+func (g *globPattern) AllowFiles() bool  { return !g.dirsOnly }
+
+// Match matches name against the pattern's base (last) segment.
+func (g *globPattern) Match(name string) bool {
+	if len(g.segments) == 0 {
+		return false
+	}
+	base := g.segments[len(g.segments)-1]
+	if base == "**" {
+		return true
+	}
+	return matchSegment(base, name)
+}
+
+// CanMatchInDir reports whether dir (a "/"-joined virtual path, relative
+// to the same root as the pattern) is a possible ancestor of a match,
+// letting a walker skip subtrees that the pattern can never reach.
+func (g *globPattern) CanMatchInDir(dir string) bool {
+	dir = strings.Trim(path.Clean("/"+filepathToSlash(dir)), "/")
+	if dir == "" || dir == "." {
+		return true
+	}
+	dirSegs := strings.Split(dir, "/")
+	for i, seg := range dirSegs {
+		if i >= len(g.segments) {
+			return g.recursive
+		}
+		patSeg := g.segments[i]
+		if patSeg == "**" {
+			return true
+		}
+		if !matchSegment(patSeg, seg) {
+			return false
+		}
+	}
+	return true
+}
+
+func filepathToSlash(p string) string {
+	return strings.Replace(p, "\\", "/", -1)
+}
+
+// matchSegment matches a single path segment against a single pattern
+// segment, expanding "{a,b,c}" brace alternation (at most one level, not
+// nested) before falling back to path.Match for "*"/"?"/"[...]" classes.
+func matchSegment(pattern, name string) bool {
+	for _, alt := range expandBraces(pattern) {
+		if ok, err := path.Match(alt, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expandBraces expands a single "{a,b,c}" group in pattern into its
+// alternatives, e.g. "*.{css,scss}" -> ["*.css", "*.scss"]. Patterns
+// without a brace group return a single-element slice unchanged.
+func expandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		return []string{pattern}
+	}
+	end := strings.IndexByte(pattern[start:], '}')
+	if end < 0 {
+		return []string{pattern}
+	}
+	end += start
+	prefix, suffix := pattern[:start], pattern[end+1:]
+	var out []string
+	for _, alt := range strings.Split(pattern[start+1:end], ",") {
+		out = append(out, prefix+alt+suffix)
+	}
+	return out
+}
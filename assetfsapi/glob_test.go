@@ -0,0 +1,86 @@
+package assetfsapi
+
+import "testing"
+
+func TestGlobPatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.css", "styles.css", true},
+		{"*.css", "styles.js", false},
+		{"*.{css,js}", "app.js", true},
+		{"*.{css,js}", "app.png", false},
+	}
+	for _, c := range cases {
+		g := NewGlobString(c.pattern)
+		if got := g.Match(c.name); got != c.want {
+			t.Errorf("NewGlobString(%q).Match(%q) = %v, want %v", c.pattern, c.name, got, c.want)
+		}
+	}
+}
+
+func TestGlobPatternDirAndRecursive(t *testing.T) {
+	g := NewGlobString("assets/vendor/**/*.css")
+	if dir := g.Dir(); dir != "assets/vendor" {
+		t.Errorf("Dir() = %q, want %q", dir, "assets/vendor")
+	}
+	if !g.IsRecursive() {
+		t.Error("IsRecursive() = false, want true for a ** pattern")
+	}
+	if g := NewGlobString("assets/*.css"); g.IsRecursive() {
+		t.Error("IsRecursive() = true, want false without **")
+	}
+}
+
+func TestGlobPatternNegatedAndDirsOnly(t *testing.T) {
+	g := NewGlobString("!assets/**")
+	if !g.Negated() {
+		t.Error("Negated() = false, want true for a leading !")
+	}
+	g2 := NewGlobString("assets/vendor/")
+	if g2.AllowFiles() {
+		t.Error("AllowFiles() = true, want false for a trailing-slash (dirs-only) pattern")
+	}
+	if !g2.AllowDirs() {
+		t.Error("AllowDirs() = false, want true")
+	}
+}
+
+func TestGlobPatternCanMatchInDir(t *testing.T) {
+	g := NewGlobString("assets/vendor/**/*.css")
+	cases := []struct {
+		dir  string
+		want bool
+	}{
+		{"assets", true},
+		{"assets/vendor", true},
+		{"assets/vendor/bootstrap", true},
+		{"assets/img", false},
+		{"other", false},
+	}
+	for _, c := range cases {
+		if got := g.CanMatchInDir(c.dir); got != c.want {
+			t.Errorf("CanMatchInDir(%q) = %v, want %v", c.dir, got, c.want)
+		}
+	}
+}
+
+// stubPattern is a GlobPattern not produced by NewGlobString, exercising
+// NewGlob's genericGlob fallback (which never prunes).
+type stubPattern struct{}
+
+func (stubPattern) Dir() string       { return "." }
+func (stubPattern) Match(string) bool { return true }
+func (stubPattern) AllowDirs() bool   { return true }
+func (stubPattern) AllowFiles() bool  { return true }
+func (stubPattern) IsRecursive() bool { return true }
+func (stubPattern) Negated() bool     { return false }
+func (stubPattern) String() string    { return "stub" }
+
+func TestNewGlobFallback(t *testing.T) {
+	g := NewGlob(stubPattern{})
+	if !g.CanMatchInDir("anything") {
+		t.Error("genericGlob.CanMatchInDir should conservatively return true")
+	}
+}
@@ -0,0 +1,52 @@
+// Package logadapter adapts third-party loggers to assetfsapi.Logger, so
+// applications can attach whichever one they already use via
+// assetfsapi.WithLogger without assetfsapi itself depending on it.
+package logadapter
+
+import (
+	"log/slog"
+
+	apexlog "github.com/apex/log"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+type apexAdapter struct {
+	log apexlog.Interface
+}
+
+// NewApexAdapter adapts an apex/log Interface (e.g. log.Log, or the
+// logger already pulled in by the sibling xbindata project) to
+// assetfsapi.Logger.
+func NewApexAdapter(log apexlog.Interface) assetfsapi.Logger {
+	return &apexAdapter{log: log}
+}
+
+func (a *apexAdapter) Debug(msg string, kv ...interface{}) { a.log.WithFields(fields(kv)).Debug(msg) }
+func (a *apexAdapter) Info(msg string, kv ...interface{})  { a.log.WithFields(fields(kv)).Info(msg) }
+func (a *apexAdapter) Warn(msg string, kv ...interface{})  { a.log.WithFields(fields(kv)).Warn(msg) }
+func (a *apexAdapter) Error(msg string, kv ...interface{}) { a.log.WithFields(fields(kv)).Error(msg) }
+
+func fields(kv []interface{}) apexlog.Fields {
+	f := make(apexlog.Fields, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if key, ok := kv[i].(string); ok {
+			f[key] = kv[i+1]
+		}
+	}
+	return f
+}
+
+type slogAdapter struct {
+	log *slog.Logger
+}
+
+// NewSlogAdapter adapts a stdlib *log/slog.Logger to assetfsapi.Logger.
+func NewSlogAdapter(log *slog.Logger) assetfsapi.Logger {
+	return &slogAdapter{log: log}
+}
+
+func (a *slogAdapter) Debug(msg string, kv ...interface{}) { a.log.Debug(msg, kv...) }
+func (a *slogAdapter) Info(msg string, kv ...interface{})  { a.log.Info(msg, kv...) }
+func (a *slogAdapter) Warn(msg string, kv ...interface{})  { a.log.Warn(msg, kv...) }
+func (a *slogAdapter) Error(msg string, kv ...interface{}) { a.log.Error(msg, kv...) }
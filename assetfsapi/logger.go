@@ -0,0 +1,43 @@
+package assetfsapi
+
+import "context"
+
+// Logger is the structured logging interface asset lookups report
+// through. Methods take a message plus alternating key/value pairs,
+// matching the convention used by apex/log and log/slog so either can
+// back it with a thin adapter (see assetfsapi/logadapter).
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// nopLogger discards everything; it is the default when no Logger has
+// been attached to a context.Context.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// NopLogger is a Logger that discards every call.
+var NopLogger Logger = nopLogger{}
+
+type loggerCtxKey struct{}
+
+// WithLogger attaches l to ctx, so every lookup made with the returned
+// context (AssetC, AssetInfoC, WalkInfoC, GlobInfoC, ...) reports through it.
+func WithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// LoggerFromContext returns the Logger attached to ctx via WithLogger, or
+// NopLogger if none was attached.
+func LoggerFromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(Logger); ok && l != nil {
+		return l
+	}
+	return NopLogger
+}
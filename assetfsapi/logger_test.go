@@ -0,0 +1,31 @@
+package assetfsapi
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingLogger struct {
+	debug, info, warn, error []string
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...interface{}) { l.debug = append(l.debug, msg) }
+func (l *recordingLogger) Info(msg string, _ ...interface{})  { l.info = append(l.info, msg) }
+func (l *recordingLogger) Warn(msg string, _ ...interface{})  { l.warn = append(l.warn, msg) }
+func (l *recordingLogger) Error(msg string, _ ...interface{}) { l.error = append(l.error, msg) }
+
+func TestLoggerFromContextDefaultsToNop(t *testing.T) {
+	if LoggerFromContext(context.Background()) != NopLogger {
+		t.Error("LoggerFromContext on a bare context should return NopLogger")
+	}
+}
+
+func TestWithLoggerRoundTrips(t *testing.T) {
+	l := &recordingLogger{}
+	ctx := WithLogger(context.Background(), l)
+	got := LoggerFromContext(ctx)
+	got.Debug("hello")
+	if len(l.debug) != 1 || l.debug[0] != "hello" {
+		t.Errorf("LoggerFromContext did not return the attached Logger, got debug=%v", l.debug)
+	}
+}
@@ -0,0 +1,270 @@
+// Package compose layers several assetfsapi.Interface values into a single
+// read-through view, mirroring the composite/overlay FS pattern used by
+// Hugo's hugofs. Lookups probe layers in order and return the first hit;
+// walks and globs union every layer, deduping by virtual path so a path
+// present in more than one layer is only reported once, from the
+// highest-priority (first) layer that has it.
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+	"github.com/moisespsena-go/os-common"
+)
+
+// FS layers N assetfsapi.Interface values. The first layer is the top of
+// the stack: it is probed first on read, and is the only one written to
+// when it is writable.
+type FS struct {
+	assetfsapi.Interface
+	layers []assetfsapi.Interface
+}
+
+// New layers the given Interfaces, top (highest priority) first.
+func New(layers ...assetfsapi.Interface) *FS {
+	if len(layers) == 0 {
+		panic("compose: at least one layer is required")
+	}
+	return &FS{Interface: layers[0], layers: layers}
+}
+
+// Layers returns the layers in priority order, top first.
+func (fs *FS) Layers() []assetfsapi.Interface {
+	return fs.layers
+}
+
+func (fs *FS) Asset(pth string) (assetfsapi.AssetInterface, error) {
+	return fs.AssetC(context.Background(), pth)
+}
+
+func (fs *FS) AssetC(ctx context.Context, pth string) (asset assetfsapi.AssetInterface, err error) {
+	for _, layer := range fs.layers {
+		if asset, err = layer.AssetC(ctx, pth); err == nil {
+			return asset, nil
+		}
+	}
+	return nil, oscommon.ErrNotFound(pth)
+}
+
+func (fs *FS) MustAsset(pth string) assetfsapi.AssetInterface {
+	a, err := fs.Asset(pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *FS) MustAssetC(ctx context.Context, pth string) assetfsapi.AssetInterface {
+	a, err := fs.AssetC(ctx, pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *FS) AssetInfo(pth string) (assetfsapi.FileInfo, error) {
+	return fs.AssetInfoC(context.Background(), pth)
+}
+
+func (fs *FS) AssetInfoC(ctx context.Context, pth string) (info assetfsapi.FileInfo, err error) {
+	for _, layer := range fs.layers {
+		if info, err = layer.AssetInfoC(ctx, pth); err == nil {
+			return info, nil
+		}
+	}
+	return nil, oscommon.ErrNotFound(pth)
+}
+
+func (fs *FS) MustAssetInfo(pth string) assetfsapi.FileInfo {
+	info, err := fs.AssetInfo(pth)
+	if err != nil {
+		panic(err)
+	}
+	return info
+}
+
+func (fs *FS) MustAssetInfoC(ctx context.Context, pth string) assetfsapi.FileInfo {
+	info, err := fs.AssetInfoC(ctx, pth)
+	if err != nil {
+		panic(err)
+	}
+	return info
+}
+
+func (fs *FS) AssetReader() assetfsapi.AssetReaderFunc {
+	return func(pth string) (io.ReadCloser, error) {
+		a, err := fs.Asset(pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+func (fs *FS) AssetReaderC() assetfsapi.AssetReaderFuncC {
+	return func(ctx context.Context, pth string) (io.ReadCloser, error) {
+		a, err := fs.AssetC(ctx, pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+// WalkInfo unions every layer's walk, in layer order, deduping by virtual
+// path so a shadowed file is only seen once, from its topmost layer.
+func (fs *FS) WalkInfo(dir string, cb assetfsapi.CbWalkInfoFunc, mode ...assetfsapi.WalkMode) error {
+	seen := map[string]bool{}
+	for _, layer := range fs.layers {
+		err := layer.WalkInfo(dir, func(info assetfsapi.FileInfo) error {
+			if seen[info.Path()] {
+				return nil
+			}
+			seen[info.Path()] = true
+			return cb(info)
+		}, mode...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkInfoC is the context-aware form of WalkInfo, unioning every layer's
+// walk the same way.
+func (fs *FS) WalkInfoC(ctx context.Context, dir string, cb assetfsapi.CbWalkInfoFunc, mode ...assetfsapi.WalkMode) error {
+	seen := map[string]bool{}
+	for _, layer := range fs.layers {
+		err := layer.WalkInfoC(ctx, dir, func(info assetfsapi.FileInfo) error {
+			if seen[info.Path()] {
+				return nil
+			}
+			seen[info.Path()] = true
+			return cb(info)
+		}, mode...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fs *FS) Walk(dir string, cb assetfsapi.CbWalkFunc, mode ...assetfsapi.WalkMode) error {
+	return fs.WalkInfo(dir, func(info assetfsapi.FileInfo) error {
+		return cb(info.Path(), info.IsDir())
+	}, mode...)
+}
+
+func (fs *FS) ReadDir(dir string, cb assetfsapi.CbWalkInfoFunc, skipDir bool) error {
+	seen := map[string]bool{}
+	for _, layer := range fs.layers {
+		err := layer.ReadDir(dir, func(info assetfsapi.FileInfo) error {
+			if seen[info.Path()] {
+				return nil
+			}
+			seen[info.Path()] = true
+			return cb(info)
+		}, skipDir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GlobInfo unions every layer's glob results the same way WalkInfo does.
+func (fs *FS) GlobInfo(pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	seen := map[string]bool{}
+	for _, layer := range fs.layers {
+		err := layer.GlobInfo(pattern, func(info assetfsapi.FileInfo) error {
+			if seen[info.Path()] {
+				return nil
+			}
+			seen[info.Path()] = true
+			return cb(info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GlobInfoC is the context-aware form of GlobInfo; see WalkInfoC.
+func (fs *FS) GlobInfoC(ctx context.Context, pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	seen := map[string]bool{}
+	for _, layer := range fs.layers {
+		err := layer.GlobInfoC(ctx, pattern, func(info assetfsapi.FileInfo) error {
+			if seen[info.Path()] {
+				return nil
+			}
+			seen[info.Path()] = true
+			return cb(info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fs *FS) Glob(pattern assetfsapi.GlobPattern, cb func(pth string, isDir bool) error) error {
+	return fs.GlobInfo(pattern, func(info assetfsapi.FileInfo) error {
+		return cb(info.Path(), info.IsDir())
+	})
+}
+
+// top returns the top layer as a WritableInterface, or an error if it
+// isn't one. Every write method below goes through it, since layers[0]
+// is the only layer FS ever writes to.
+func (fs *FS) top() (assetfsapi.WritableInterface, error) {
+	w, ok := fs.layers[0].(assetfsapi.WritableInterface)
+	if !ok {
+		return nil, fmt.Errorf("compose: top layer %T is not writable", fs.layers[0])
+	}
+	return w, nil
+}
+
+// Create implements assetfsapi.WritableInterface by writing through to
+// the top layer.
+func (fs *FS) Create(pth string) (io.WriteCloser, error) {
+	w, err := fs.top()
+	if err != nil {
+		return nil, err
+	}
+	return w.Create(pth)
+}
+
+// Mkdir implements assetfsapi.WritableInterface by writing through to
+// the top layer.
+func (fs *FS) Mkdir(pth string) error {
+	w, err := fs.top()
+	if err != nil {
+		return err
+	}
+	return w.Mkdir(pth)
+}
+
+// Remove implements assetfsapi.WritableInterface by writing through to
+// the top layer. It does not remove pth from lower layers, so a path
+// shadowed by the top layer may reappear once its copy there is gone.
+func (fs *FS) Remove(pth string) error {
+	w, err := fs.top()
+	if err != nil {
+		return err
+	}
+	return w.Remove(pth)
+}
+
+// Rename implements assetfsapi.WritableInterface by writing through to
+// the top layer.
+func (fs *FS) Rename(oldPath, newPath string) error {
+	w, err := fs.top()
+	if err != nil {
+		return err
+	}
+	return w.Rename(oldPath, newPath)
+}
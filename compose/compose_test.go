@@ -0,0 +1,58 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+	"github.com/moisespsena-go/assetfs/memfs"
+)
+
+// readOnlyLayer wraps an assetfsapi.Interface without promoting any write
+// methods the concrete value underneath might have, so it never satisfies
+// assetfsapi.WritableInterface.
+type readOnlyLayer struct {
+	assetfsapi.Interface
+}
+
+func TestCreateWritesThroughTopLayer(t *testing.T) {
+	top := memfs.New("")
+	bottom := memfs.New("")
+	bottom.WriteFile("shared.txt", []byte("from bottom"))
+
+	fs := New(top, bottom)
+
+	out, err := fs.Create("new.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := out.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := top.Asset("new.txt"); err != nil {
+		t.Fatalf("Create should write through to the top layer: %v", err)
+	}
+	if _, err := bottom.Asset("new.txt"); err == nil {
+		t.Error("Create should not write to a lower layer")
+	}
+}
+
+func TestMkdirRemoveRenameRequireWritableTopLayer(t *testing.T) {
+	fs := New(readOnlyLayer{memfs.New("")})
+
+	if err := fs.Mkdir("dir"); err == nil {
+		t.Error("Mkdir on a non-writable top layer should return an error")
+	}
+	if err := fs.Remove("dir"); err == nil {
+		t.Error("Remove on a non-writable top layer should return an error")
+	}
+	if err := fs.Rename("a", "b"); err == nil {
+		t.Error("Rename on a non-writable top layer should return an error")
+	}
+	if _, err := fs.Create("f"); err == nil {
+		t.Error("Create on a non-writable top layer should return an error")
+	}
+}
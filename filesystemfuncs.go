@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/moisespsena-go/assetfs/local"
 
@@ -27,20 +28,55 @@ func filesystemGlob(fs *AssetFileSystem, pattern assetfsapi.GlobPattern, cb func
 
 // Names list matched files from assetfs
 func filesystemGlobInfo(fs *AssetFileSystem, pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	return filesystemGlobInfoC(context.Background(), fs, pattern, cb)
+}
+
+// filesystemGlobInfoC is the context-aware form of filesystemGlobInfo; it
+// reports a span (pattern, directories pruned, matches, elapsed) through
+// the assetfsapi.Logger attached to ctx, if any.
+func filesystemGlobInfoC(ctx context.Context, fs *AssetFileSystem, pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	start := time.Now()
+	logger := assetfsapi.LoggerFromContext(ctx)
+	matches := 0
 	set := make(map[string]bool)
+	// When pattern also implements assetfsapi.Glob, consult CanMatchInDir
+	// on every directory the walk visits so subtrees it can never satisfy
+	// (e.g. "assets/img" under "assets/vendor/**/*.css") are recorded in
+	// pruned and every descendant is skipped without being matched. A
+	// negated pattern matches everything Match doesn't, so CanMatchInDir's
+	// pruning (computed against the un-negated segments) cannot be applied.
+	g, canPrune := pattern.(assetfsapi.Glob)
+	canPrune = canPrune && !pattern.Negated()
+	var pruned []string
+	isPruned := func(pth string) bool {
+		for _, p := range pruned {
+			if pth == p || strings.HasPrefix(pth, p+string(filepath.Separator)) {
+				return true
+			}
+		}
+		return false
+	}
 	cb2 := func(info assetfsapi.FileInfo) error {
+		pth := info.Path()
+		if canPrune && isPruned(pth) {
+			return nil
+		}
 		if info.IsDir() {
-			if !pattern.AllowDirs() {
-				return nil
+			if canPrune && !g.CanMatchInDir(filepath.ToSlash(pth)) {
+				pruned = append(pruned, pth)
+				return filepath.SkipDir
 			}
-		} else {
-			if !pattern.AllowFiles() {
+			if !pattern.AllowDirs() {
 				return nil
 			}
+		} else if !pattern.AllowFiles() {
+			return nil
 		}
-		pth := info.Path()
-		ok := pattern.Match(filepath.Base(pth))
-		if !ok {
+		matched := pattern.Match(filepath.Base(pth))
+		if pattern.Negated() {
+			matched = !matched
+		}
+		if !matched {
 			return nil
 		}
 		if _, ok := set[pth]; !ok {
@@ -48,13 +84,22 @@ func filesystemGlobInfo(fs *AssetFileSystem, pattern assetfsapi.GlobPattern, cb
 				return err
 			}
 			set[pth] = true
+			matches++
 		}
 		return nil
 	}
+	var err error
 	if pattern.IsRecursive() {
-		return fs.WalkInfo(pattern.Dir(), cb2, assetfsapi.WalkAll^assetfsapi.WalkDirs)
+		err = fs.WalkInfo(pattern.Dir(), cb2, assetfsapi.WalkAll)
+	} else {
+		err = fs.readDir(pattern.Dir(), cb2, true, true)
+	}
+	if err != nil {
+		logger.Error("assetfs: glob failed", "pattern", pattern.String(), "err", err, "elapsed", time.Since(start))
+		return err
 	}
-	return fs.readDir(pattern.Dir(), cb2, true, true)
+	logger.Debug("assetfs: glob", "pattern", pattern.String(), "matches", matches, "pruned", len(pruned), "elapsed", time.Since(start))
+	return nil
 }
 
 // Asset get content with name from assetfs
@@ -72,29 +117,54 @@ func filesystemAsset(ctx context.Context, fs *AssetFileSystem, name string) (ass
 }
 
 func filesystemAssetInfo(ctx context.Context, fs *AssetFileSystem, pth string) (info assetfsapi.FileInfo, err error) {
+	start := time.Now()
+	logger := assetfsapi.LoggerFromContext(ctx)
 	var (
-		r    string
-		stat os.FileInfo
+		r      string
+		stat   os.FileInfo
+		probed []string
 	)
 	dir, base := path.Split(pth)
-	err = fs.PathsFrom(ctx, dir, func(pth string) (err error) {
-		pth = filepath.FromSlash(path.Join(pth, base))
-		if stat, err = os.Stat(pth); err == nil {
-			r = pth
+	err = fs.PathsFrom(ctx, dir, func(candidate string) (err error) {
+		probed = append(probed, candidate)
+		real := filepath.FromSlash(path.Join(candidate, base))
+		if stat, err = os.Stat(real); err == nil {
+			r = real
 			return io.EOF
 		}
 		return nil
 	})
 	if err != nil && err != io.EOF {
+		logger.Error("assetfs: lookup failed", "path", pth, "err", err, "elapsed", time.Since(start))
 		return nil, err
 	}
 	if r == "" {
+		if info, ok, mErr := mountAssetInfo(ctx, fs, pth); mErr != nil {
+			logger.Error("assetfs: mount lookup failed", "path", pth, "err", mErr, "elapsed", time.Since(start))
+			return nil, mErr
+		} else if ok {
+			logger.Debug("assetfs: resolved", "path", pth, "source", "mount", "real", info.RealPath(), "elapsed", time.Since(start))
+			return info, nil
+		}
+		logger.Warn("assetfs: not found", "path", pth, "probed", probed, "elapsed", time.Since(start))
 		return nil, oscommon.ErrNotFound(pth)
 	}
+	logger.Debug("assetfs: resolved", "path", pth, "source", "local", "real", r, "elapsed", time.Since(start))
 	return &RealFileInfo{basicFileInfo(pth, stat), r}, nil
 }
 
-func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFunc, mode assetfsapi.WalkMode) (err error) {
+// filesystemWalk walks fs starting at dir. It has no context of its own;
+// use filesystemWalkC when a context.Context (and its attached
+// assetfsapi.Logger) is available.
+func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFunc, mode assetfsapi.WalkMode) error {
+	return filesystemWalkC(context.Background(), fs, dir, cb, mode)
+}
+
+// filesystemWalkC is the context-aware form of filesystemWalk: ctx is
+// threaded through every recursive call (namespaces, parent lookup) and
+// into mountWalk, so a Logger attached via assetfsapi.WithLogger sees the
+// whole walk as one traced operation.
+func filesystemWalkC(ctx context.Context, fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFunc, mode assetfsapi.WalkMode) (err error) {
 	if dir == "" {
 		dir = "."
 	}
@@ -102,7 +172,7 @@ func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFun
 	if dir == "." {
 		if fs.nameSpaces != nil {
 			for _, ns := range fs.nameSpaces {
-				err = filesystemWalk(ns, ".", func(info assetfsapi.FileInfo) error {
+				err = filesystemWalkC(ctx, ns, ".", func(info assetfsapi.FileInfo) error {
 					npth := strings.TrimPrefix(ns.path, fs.path)
 					if npth[0] == '/' {
 						npth = npth[1:]
@@ -154,11 +224,16 @@ func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFun
 		if err != nil {
 			return
 		}
+
+		err = mountWalk(ctx, fs, ".", cb, mode, map[string]bool{})
+		if err != nil {
+			return
+		}
 	} else {
 		if mode.IsNameSpacesLookUp() && fs.nameSpaces != nil {
 			parts := strings.SplitN(dir, string(os.PathSeparator), 2)
 			if ns, ok := fs.nameSpaces[parts[0]]; ok {
-				err = filesystemWalk(ns, parts[1], cb, mode|assetfsapi.WalkNameSpacesLookUp^assetfsapi.WalkParentLookUp)
+				err = filesystemWalkC(ctx, ns, parts[1], cb, mode|assetfsapi.WalkNameSpacesLookUp^assetfsapi.WalkParentLookUp)
 				if err != nil {
 					return err
 				}
@@ -201,6 +276,11 @@ func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFun
 		if err != nil {
 			return
 		}
+
+		err = mountWalk(ctx, fs, dir, cb, mode, map[string]bool{})
+		if err != nil {
+			return
+		}
 	}
 
 	if err == nil && fs.parent != nil && mode.IsParentLookUp() {
@@ -212,7 +292,7 @@ func filesystemWalk(fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFun
 		if mode.IsNameSpacesLookUp() {
 			mode ^= assetfsapi.WalkNameSpacesLookUp
 		}
-		return filesystemWalk(fs.parent.(*AssetFileSystem), dir, cb, mode)
+		return filesystemWalkC(ctx, fs.parent.(*AssetFileSystem), dir, cb, mode)
 	}
 	return
 }
@@ -0,0 +1,551 @@
+// Package memfs implements assetfsapi.Interface entirely in memory. It
+// exists so tests and tools can build an asset tree without touching disk,
+// and so applications can assemble a throwaway or hot-swappable provider at
+// runtime (see assetfs/compose for layering one of these over a real
+// AssetFileSystem).
+package memfs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+	"github.com/moisespsena-go/os-common"
+)
+
+type entry struct {
+	data    []byte
+	modTime time.Time
+	isDir   bool
+}
+
+// FileSystem is an in-memory assetfsapi.Interface. The zero value is not
+// usable; create one with New.
+type FileSystem struct {
+	mu      sync.RWMutex
+	files   map[string]*entry
+	path    string
+	parent  assetfsapi.Interface
+	nameSpace string
+	nameSpaces map[string]assetfsapi.NameSpacedInterface
+	providers  []assetfsapi.Interface
+	plugins    []assetfsapi.Plugin
+	sources    assetfsapi.LocalSourceRegister
+	mounts     []assetfsapi.Mount
+}
+
+// New creates an empty in-memory file system rooted at path (used only to
+// answer GetPath/GetParent bookkeeping, matching AssetFileSystem).
+func New(path string) *FileSystem {
+	return &FileSystem{
+		files:      map[string]*entry{".": {isDir: true, modTime: time.Now()}},
+		path:       path,
+		nameSpaces: map[string]assetfsapi.NameSpacedInterface{},
+	}
+}
+
+func clean(pth string) string {
+	pth = path.Clean("/" + filepath_ToSlash(pth))
+	if pth == "/" {
+		return "."
+	}
+	return strings.TrimPrefix(pth, "/")
+}
+
+func filepath_ToSlash(pth string) string {
+	return strings.Replace(pth, "\\", "/", -1)
+}
+
+// WriteFile stores data at pth, creating any intermediate directories. It is
+// the primary way tests populate a FileSystem.
+func (fs *FileSystem) WriteFile(pth string, data []byte, modTime ...time.Time) {
+	pth = clean(pth)
+	mt := time.Now()
+	if len(modTime) > 0 {
+		mt = modTime[0]
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.files[pth] = &entry{data: data, modTime: mt}
+	for dir := path.Dir(pth); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, ok := fs.files[dir]; !ok {
+			fs.files[dir] = &entry{isDir: true, modTime: mt}
+		} else {
+			break
+		}
+	}
+}
+
+// Remove implements assetfsapi.WritableInterface, deleting pth from the
+// file system. If pth is a directory, its children are deleted with it. It
+// is not an error to remove a path that does not exist.
+func (fs *FileSystem) Remove(pth string) error {
+	pth = clean(pth)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	prefix := pth + "/"
+	for k := range fs.files {
+		if k == pth || strings.HasPrefix(k, prefix) {
+			delete(fs.files, k)
+		}
+	}
+	return nil
+}
+
+// Create implements assetfsapi.WritableInterface, returning a writer that
+// stores its content under pth once closed.
+func (fs *FileSystem) Create(pth string) (io.WriteCloser, error) {
+	return &memWriter{fs: fs, pth: clean(pth)}, nil
+}
+
+// Mkdir implements assetfsapi.WritableInterface, creating pth and any
+// missing parent directories.
+func (fs *FileSystem) Mkdir(pth string) error {
+	pth = clean(pth)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if e, ok := fs.files[pth]; ok {
+		if !e.isDir {
+			return fmt.Errorf("memfs: %s already exists and is not a directory", pth)
+		}
+		return nil
+	}
+	mt := time.Now()
+	fs.files[pth] = &entry{isDir: true, modTime: mt}
+	for dir := path.Dir(pth); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, ok := fs.files[dir]; ok {
+			break
+		}
+		fs.files[dir] = &entry{isDir: true, modTime: mt}
+	}
+	return nil
+}
+
+// Rename implements assetfsapi.WritableInterface. If oldPth is a
+// directory, its children are moved along with it, keeping their paths
+// relative to oldPth unchanged under newPth.
+func (fs *FileSystem) Rename(oldPth, newPth string) error {
+	oldPth, newPth = clean(oldPth), clean(newPth)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	e, ok := fs.files[oldPth]
+	if !ok {
+		return oscommon.ErrNotFound(oldPth)
+	}
+	prefix := oldPth + "/"
+	var children []string
+	for k := range fs.files {
+		if strings.HasPrefix(k, prefix) {
+			children = append(children, k)
+		}
+	}
+	delete(fs.files, oldPth)
+	fs.files[newPth] = e
+	for _, k := range children {
+		fs.files[newPth+"/"+strings.TrimPrefix(k, prefix)] = fs.files[k]
+		delete(fs.files, k)
+	}
+	return nil
+}
+
+// memWriter buffers writes until Close, then commits them to fs as a
+// single entry, matching the all-at-once semantics of os.Create + write +
+// close for the common case of serving a local writable source.
+type memWriter struct {
+	fs  *FileSystem
+	pth string
+	buf bytes.Buffer
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *memWriter) Close() error {
+	w.fs.WriteFile(w.pth, w.buf.Bytes())
+	return nil
+}
+
+func (fs *FileSystem) get(pth string) (*entry, bool) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	e, ok := fs.files[clean(pth)]
+	return e, ok
+}
+
+func (fs *FileSystem) info(pth string) (assetfsapi.FileInfo, error) {
+	pth = clean(pth)
+	e, ok := fs.get(pth)
+	if !ok {
+		return nil, oscommon.ErrNotFound(pth)
+	}
+	return &fileInfo{fs: fs, pth: pth, e: e}, nil
+}
+
+func (fs *FileSystem) Asset(pth string) (assetfsapi.AssetInterface, error) {
+	return fs.AssetC(context.Background(), pth)
+}
+
+func (fs *FileSystem) AssetC(_ context.Context, pth string) (assetfsapi.AssetInterface, error) {
+	info, err := fs.info(pth)
+	if err != nil {
+		return nil, err
+	}
+	return &asset{fs: fs, info: info.(*fileInfo)}, nil
+}
+
+func (fs *FileSystem) MustAsset(pth string) assetfsapi.AssetInterface {
+	a, err := fs.Asset(pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *FileSystem) MustAssetC(ctx context.Context, pth string) assetfsapi.AssetInterface {
+	a, err := fs.AssetC(ctx, pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *FileSystem) AssetInfo(pth string) (assetfsapi.FileInfo, error) {
+	return fs.info(pth)
+}
+
+func (fs *FileSystem) AssetInfoC(_ context.Context, pth string) (assetfsapi.FileInfo, error) {
+	return fs.info(pth)
+}
+
+func (fs *FileSystem) MustAssetInfo(pth string) assetfsapi.FileInfo {
+	info, err := fs.info(pth)
+	if err != nil {
+		panic(err)
+	}
+	return info
+}
+
+func (fs *FileSystem) MustAssetInfoC(ctx context.Context, pth string) assetfsapi.FileInfo {
+	return fs.MustAssetInfo(pth)
+}
+
+func (fs *FileSystem) AssetReader() assetfsapi.AssetReaderFunc {
+	return func(pth string) (io.ReadCloser, error) {
+		a, err := fs.Asset(pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+func (fs *FileSystem) AssetReaderC() assetfsapi.AssetReaderFuncC {
+	return func(ctx context.Context, pth string) (io.ReadCloser, error) {
+		a, err := fs.AssetC(ctx, pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+func (fs *FileSystem) Provider(providers ...assetfsapi.Interface) {
+	fs.providers = append(fs.providers, providers...)
+}
+
+func (fs *FileSystem) Providers() []assetfsapi.Interface {
+	return fs.providers
+}
+
+func (fs *FileSystem) Compile() error {
+	return nil
+}
+
+func (fs *FileSystem) GetPath() string {
+	return fs.path
+}
+
+func (fs *FileSystem) GetParent() assetfsapi.Interface {
+	return fs.parent
+}
+
+func (fs *FileSystem) LocalSources() assetfsapi.LocalSourceRegister {
+	return fs.sources
+}
+
+func (fs *FileSystem) SetLocalSources(sources assetfsapi.LocalSourceRegister) {
+	fs.sources = sources
+}
+
+func (fs *FileSystem) RegisterPlugin(plugins ...assetfsapi.Plugin) {
+	for _, p := range plugins {
+		p.Init(fs)
+		fs.plugins = append(fs.plugins, p)
+	}
+}
+
+func (fs *FileSystem) GetNameSpace(nameSpace string) (assetfsapi.NameSpacedInterface, error) {
+	ns, ok := fs.nameSpaces[nameSpace]
+	if !ok {
+		return nil, oscommon.ErrNotFound(nameSpace)
+	}
+	return ns, nil
+}
+
+func (fs *FileSystem) NameSpace(nameSpace string) assetfsapi.NameSpacedInterface {
+	ns, _ := fs.GetNameSpace(nameSpace)
+	return ns
+}
+
+func (fs *FileSystem) NameSpaces() []assetfsapi.NameSpacedInterface {
+	out := make([]assetfsapi.NameSpacedInterface, 0, len(fs.nameSpaces))
+	for _, ns := range fs.nameSpaces {
+		out = append(out, ns)
+	}
+	return out
+}
+
+// Mount records a mount so that Mounts and MountsAt report it. FileSystem
+// does not resolve mounts: its lookups, walks and globs only see the files
+// stored in memory.
+func (fs *FileSystem) Mount(source interface{}, target string, opts ...assetfsapi.MountOption) error {
+	m := assetfsapi.Mount{Source: source, Target: clean(target)}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	fs.mounts = append(fs.mounts, m)
+	return nil
+}
+
+func (fs *FileSystem) Mounts() []assetfsapi.Mount {
+	return fs.mounts
+}
+
+func (fs *FileSystem) MountsAt(target string) []assetfsapi.Mount {
+	target = clean(target)
+	var out []assetfsapi.Mount
+	for _, m := range fs.mounts {
+		if target == "." || m.Target == "." || m.Target == target ||
+			strings.HasPrefix(m.Target, target+"/") || strings.HasPrefix(target, m.Target+"/") {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (fs *FileSystem) Walk(dir string, cb assetfsapi.CbWalkFunc, mode ...assetfsapi.WalkMode) error {
+	return fs.WalkInfo(dir, func(info assetfsapi.FileInfo) error {
+		return cb(info.Path(), info.IsDir())
+	}, mode...)
+}
+
+func (fs *FileSystem) WalkInfo(dir string, cb assetfsapi.CbWalkInfoFunc, mode ...assetfsapi.WalkMode) error {
+	dir = clean(dir)
+	m := assetfsapi.WalkAll
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+	fs.mu.RLock()
+	paths := make([]string, 0, len(fs.files))
+	for pth := range fs.files {
+		paths = append(paths, pth)
+	}
+	fs.mu.RUnlock()
+	sort.Strings(paths)
+	if m.IsReverse() {
+		sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	}
+	for _, pth := range paths {
+		if pth == "." {
+			continue
+		}
+		if dir != "." && pth != dir && !strings.HasPrefix(pth, dir+"/") {
+			continue
+		}
+		info, err := fs.info(pth)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			if !m.IsDirs() {
+				continue
+			}
+		} else if !m.IsFiles() {
+			continue
+		}
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkInfoC is the context-aware form of WalkInfo. FileSystem has no
+// Logger of its own to report through, so ctx is accepted only for
+// assetfsapi.TraversableInterface conformance and otherwise ignored.
+func (fs *FileSystem) WalkInfoC(_ context.Context, dir string, cb assetfsapi.CbWalkInfoFunc, mode ...assetfsapi.WalkMode) error {
+	return fs.WalkInfo(dir, cb, mode...)
+}
+
+func (fs *FileSystem) ReadDir(dir string, cb assetfsapi.CbWalkInfoFunc, skipDir bool) error {
+	dir = clean(dir)
+	fs.mu.RLock()
+	paths := make([]string, 0, len(fs.files))
+	for pth := range fs.files {
+		paths = append(paths, pth)
+	}
+	fs.mu.RUnlock()
+	sort.Strings(paths)
+	for _, pth := range paths {
+		if pth == "." || path.Dir(pth) != dir {
+			continue
+		}
+		info, err := fs.info(pth)
+		if err != nil {
+			continue
+		}
+		if skipDir && info.IsDir() {
+			continue
+		}
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fs *FileSystem) Glob(pattern assetfsapi.GlobPattern, cb func(pth string, isDir bool) error) error {
+	return fs.GlobInfo(pattern, func(info assetfsapi.FileInfo) error {
+		return cb(info.Path(), info.IsDir())
+	})
+}
+
+func (fs *FileSystem) GlobInfo(pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	return fs.WalkInfo(pattern.Dir(), func(info assetfsapi.FileInfo) error {
+		if info.IsDir() {
+			if !pattern.AllowDirs() {
+				return nil
+			}
+		} else if !pattern.AllowFiles() {
+			return nil
+		}
+		matched := pattern.Match(path.Base(info.Path()))
+		if pattern.Negated() {
+			matched = !matched
+		}
+		if !matched {
+			return nil
+		}
+		return cb(info)
+	}, assetfsapi.WalkAll)
+}
+
+// GlobInfoC is the context-aware form of GlobInfo; see WalkInfoC.
+func (fs *FileSystem) GlobInfoC(_ context.Context, pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	return fs.GlobInfo(pattern, cb)
+}
+
+func (fs *FileSystem) NewGlob(pattern assetfsapi.GlobPattern) assetfsapi.Glob {
+	return assetfsapi.NewGlob(pattern)
+}
+
+func (fs *FileSystem) NewGlobString(pattern string) assetfsapi.Glob {
+	return assetfsapi.NewGlobString(pattern)
+}
+
+func (fs *FileSystem) DumpFiles(cb func(info assetfsapi.FileInfo) error) error {
+	return fs.WalkInfo(".", func(info assetfsapi.FileInfo) error {
+		if info.IsDir() {
+			return nil
+		}
+		return cb(info)
+	})
+}
+
+func (fs *FileSystem) Dump(cb func(info assetfsapi.FileInfo) error, ignore ...func(pth string) bool) error {
+	return fs.WalkInfo(".", func(info assetfsapi.FileInfo) error {
+		for _, ig := range ignore {
+			if ig(info.Path()) {
+				return nil
+			}
+		}
+		return cb(info)
+	})
+}
+
+func (fs *FileSystem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pth := clean(r.URL.Path)
+	info, err := fs.info(pth)
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	rd, err := info.Reader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rd.Close()
+	data, err := ioutil.ReadAll(rd)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, path.Base(pth), info.ModTime(), bytes.NewReader(data))
+}
+
+type fileInfo struct {
+	fs  *FileSystem
+	pth string
+	e   *entry
+}
+
+func (i *fileInfo) Path() string { return i.pth }
+func (i *fileInfo) Name() string { return path.Base(i.pth) }
+func (i *fileInfo) Size() int64  { return int64(len(i.e.data)) }
+func (i *fileInfo) Mode() os.FileMode {
+	if i.e.isDir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+func (i *fileInfo) ModTime() time.Time { return i.e.modTime }
+func (i *fileInfo) IsDir() bool        { return i.e.isDir }
+func (i *fileInfo) Sys() interface{}   { return nil }
+func (i *fileInfo) RealPath() string   { return "memfs://" + i.pth }
+func (i *fileInfo) GetFileInfo() os.FileInfo { return i }
+func (i *fileInfo) Type() assetfsapi.FileType {
+	if i.e.isDir {
+		return assetfsapi.FileTypeDir
+	}
+	return assetfsapi.FileTypeNormal
+}
+func (i *fileInfo) Meta() assetfsapi.MountMeta { return nil }
+func (i *fileInfo) Reader() (io.ReadCloser, error) {
+	if i.e.isDir {
+		return nil, oscommon.ErrNotFound(i.pth)
+	}
+	return ioutil.NopCloser(bytes.NewReader(i.e.data)), nil
+}
+
+type asset struct {
+	fs   *FileSystem
+	info *fileInfo
+}
+
+func (a *asset) Name() string { return a.info.Name() }
+func (a *asset) Path() string { return a.info.Path() }
+func (a *asset) Reader() (io.ReadCloser, error) {
+	return a.info.Reader()
+}
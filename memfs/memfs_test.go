@@ -0,0 +1,126 @@
+package memfs
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+func TestNewGlobWrapsNonGlobPattern(t *testing.T) {
+	fs := New("")
+	// The anonymous struct exposes only the GlobPattern methods, so it is
+	// not already a Glob and NewGlob has to wrap it.
+	g := fs.NewGlob(struct{ assetfsapi.GlobPattern }{assetfsapi.NewGlobString("*.css")})
+	if !g.CanMatchInDir("assets") {
+		t.Error("CanMatchInDir should be usable on the value NewGlob returns")
+	}
+	if !g.Match("app.css") {
+		t.Error("Match should still behave like the wrapped pattern")
+	}
+}
+
+func TestWalkInfoVisitsDirsAndFiles(t *testing.T) {
+	fs := New("")
+	fs.WriteFile("assets/app.css", []byte("body{}"))
+	fs.WriteFile("assets/vendor/lib.js", []byte("var x;"))
+
+	var paths []string
+	err := fs.WalkInfo(".", func(info assetfsapi.FileInfo) error {
+		paths = append(paths, info.Path())
+		return nil
+	}, assetfsapi.WalkAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	want := []string{"assets", "assets/app.css", "assets/vendor", "assets/vendor/lib.js"}
+	if len(paths) != len(want) {
+		t.Fatalf("WalkInfo visited %v, want %v", paths, want)
+	}
+	for i, p := range want {
+		if paths[i] != p {
+			t.Fatalf("WalkInfo visited %v, want %v", paths, want)
+		}
+	}
+}
+
+func TestRenameRecursesIntoDirectories(t *testing.T) {
+	fs := New("")
+	fs.WriteFile("src/a.txt", []byte("a"))
+	fs.WriteFile("src/nested/b.txt", []byte("b"))
+
+	if err := fs.Rename("src", "dst"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pth := range []string{"dst", "dst/a.txt", "dst/nested", "dst/nested/b.txt"} {
+		if _, ok := fs.get(pth); !ok {
+			t.Errorf("expected %s to exist after Rename", pth)
+		}
+	}
+	for _, pth := range []string{"src", "src/a.txt", "src/nested", "src/nested/b.txt"} {
+		if _, ok := fs.get(pth); ok {
+			t.Errorf("expected %s to be gone after Rename", pth)
+		}
+	}
+}
+
+func TestRemoveRecursesIntoDirectories(t *testing.T) {
+	fs := New("")
+	fs.WriteFile("src/a.txt", []byte("a"))
+	fs.WriteFile("src/nested/b.txt", []byte("b"))
+	fs.WriteFile("other.txt", []byte("o"))
+
+	if err := fs.Remove("src"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pth := range []string{"src", "src/a.txt", "src/nested", "src/nested/b.txt"} {
+		if _, ok := fs.get(pth); ok {
+			t.Errorf("expected %s to be gone after Remove", pth)
+		}
+	}
+	if _, ok := fs.get("other.txt"); !ok {
+		t.Error("Remove should not touch unrelated paths")
+	}
+}
+
+func TestGlobInfoHonorsNegatedPattern(t *testing.T) {
+	fs := New("")
+	fs.WriteFile("assets/app.css", []byte("body{}"))
+	fs.WriteFile("assets/app.js", []byte("var x;"))
+
+	var matched []string
+	err := fs.GlobInfo(assetfsapi.NewGlobString("!assets/*.css"), func(info assetfsapi.FileInfo) error {
+		if !info.IsDir() {
+			matched = append(matched, info.Path())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0] != "assets/app.js" {
+		t.Errorf("GlobInfo(\"!assets/*.css\") matched %v, want [assets/app.js]", matched)
+	}
+}
+
+func TestWalkInfoDefaultsToWalkAllWithoutMode(t *testing.T) {
+	fs := New("")
+	fs.WriteFile("a.txt", []byte("a"))
+
+	var sawFile bool
+	err := fs.WalkInfo(".", func(info assetfsapi.FileInfo) error {
+		if !info.IsDir() {
+			sawFile = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sawFile {
+		t.Error("WalkInfo with no mode given should still visit files")
+	}
+}
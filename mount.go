@@ -0,0 +1,289 @@
+package assetfs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+// mountTable holds the mounts registered on an AssetFileSystem, in
+// registration order. Lookups probe the table from last to first so later
+// mounts take precedence over earlier ones at the same target, matching the
+// overlay semantics RegisterPath/PrependPath already use for paths.
+type mountTable struct {
+	mounts []assetfsapi.Mount
+}
+
+func (t *mountTable) add(m assetfsapi.Mount) {
+	t.mounts = append(t.mounts, m)
+}
+
+// at returns the mounts whose target is dir, lies below dir, or is an
+// ancestor of dir.
+func (t *mountTable) at(dir string) []assetfsapi.Mount {
+	dir = path.Clean(filepath.ToSlash(dir))
+	var out []assetfsapi.Mount
+	for _, m := range t.mounts {
+		target := path.Clean(filepath.ToSlash(m.Target))
+		if dir == target || dir == "." || target == "." || strings.HasPrefix(target, dir+"/") || strings.HasPrefix(dir, target+"/") {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// mountCandidate is a real filesystem path realPathsFor resolved for a
+// lookup, paired with the Mount that contributed it so callers can honor
+// its Meta.
+type mountCandidate struct {
+	real string
+	meta assetfsapi.MountMeta
+}
+
+// realPathsFor translates pth (a virtual path) into the real filesystem
+// paths contributed by mounts that cover it, most recently registered
+// first. A mount whose source is another assetfsapi.Interface contributes
+// no candidate here; only mountWalk reads through such mounts.
+func (t *mountTable) realPathsFor(pth string) (candidates []mountCandidate) {
+	pth = path.Clean(filepath.ToSlash(pth))
+	for i := len(t.mounts) - 1; i >= 0; i-- {
+		m := t.mounts[i]
+		target := path.Clean(filepath.ToSlash(m.Target))
+		var rel string
+		switch {
+		case target == ".":
+			rel = pth
+		case pth == target:
+			rel = ""
+		case strings.HasPrefix(pth, target+"/"):
+			rel = pth[len(target)+1:]
+		default:
+			continue
+		}
+		if m.Exclude != nil && m.Exclude.Match(filepath.Base(rel)) {
+			continue
+		}
+		if m.Include != nil && !m.Include.Match(filepath.Base(rel)) {
+			continue
+		}
+		if src, ok := m.Source.(string); ok {
+			candidates = append(candidates, mountCandidate{filepath.Join(src, filepath.FromSlash(rel)), m.Meta})
+		}
+	}
+	return
+}
+
+// mountCovers reports whether pth falls under m's target, using the same
+// segment-boundary rule realPathsFor uses to pick candidates.
+func mountCovers(m assetfsapi.Mount, pth string) bool {
+	target := path.Clean(filepath.ToSlash(m.Target))
+	if target == "." || pth == target {
+		return true
+	}
+	return strings.HasPrefix(pth, target+"/")
+}
+
+// readOnly reports whether pth is covered by a mount registered with
+// MountReadOnly(true).
+func (t *mountTable) readOnly(pth string) bool {
+	pth = path.Clean(filepath.ToSlash(pth))
+	for _, m := range t.mounts {
+		if m.ReadOnly && mountCovers(m, pth) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mount implements assetfsapi.Mounter, grafting source onto target.
+func (fs *AssetFileSystem) Mount(source interface{}, target string, opts ...assetfsapi.MountOption) error {
+	switch source.(type) {
+	case string, assetfsapi.Interface:
+	default:
+		return fmt.Errorf("assetfs: mount source must be a string path or assetfsapi.Interface, got %T", source)
+	}
+	m := assetfsapi.Mount{Source: source, Target: path.Clean(filepath.ToSlash(target))}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	if fs.mounts == nil {
+		fs.mounts = &mountTable{}
+	}
+	fs.mounts.add(m)
+	return nil
+}
+
+// Mounts implements assetfsapi.Mounter.
+func (fs *AssetFileSystem) Mounts() []assetfsapi.Mount {
+	if fs.mounts == nil {
+		return nil
+	}
+	out := make([]assetfsapi.Mount, len(fs.mounts.mounts))
+	copy(out, fs.mounts.mounts)
+	return out
+}
+
+// MountsAt implements assetfsapi.Mounter.
+func (fs *AssetFileSystem) MountsAt(target string) []assetfsapi.Mount {
+	if fs.mounts == nil {
+		return nil
+	}
+	return fs.mounts.at(target)
+}
+
+// mountBlocksWrite reports whether pth is covered by a mount registered
+// with MountReadOnly(true), in which case writable.go's write methods must
+// reject it.
+func (fs *AssetFileSystem) mountBlocksWrite(pth string) bool {
+	return fs.mounts != nil && fs.mounts.readOnly(pth)
+}
+
+// mountFileInfo decorates a FileInfo resolved through a mount, overriding
+// Meta() to surface the Mount's MountWithMeta value. Everything else
+// delegates to the wrapped FileInfo unchanged.
+type mountFileInfo struct {
+	assetfsapi.FileInfo
+	meta assetfsapi.MountMeta
+}
+
+func (i *mountFileInfo) Meta() assetfsapi.MountMeta { return i.meta }
+
+// withMountMeta wraps info so Meta() reports meta, unless meta is nil, in
+// which case info is returned unchanged.
+func withMountMeta(info assetfsapi.FileInfo, meta assetfsapi.MountMeta) assetfsapi.FileInfo {
+	if meta == nil {
+		return info
+	}
+	return &mountFileInfo{FileInfo: info, meta: meta}
+}
+
+// mountAssetInfo probes the mount table for pth, returning the first mount
+// source that has a matching real file. It mirrors filesystemAssetInfo's
+// contract: ok is false when no mount covers pth. Only mounts whose source
+// is a real filesystem path are probed; a mount whose source is another
+// assetfsapi.Interface is not consulted. ctx is used only for the attached
+// assetfsapi.Logger, if any.
+func mountAssetInfo(ctx context.Context, fs *AssetFileSystem, pth string) (info assetfsapi.FileInfo, ok bool, err error) {
+	if fs.mounts == nil {
+		return nil, false, nil
+	}
+	logger := assetfsapi.LoggerFromContext(ctx)
+	candidates := fs.mounts.realPathsFor(pth)
+	for _, c := range candidates {
+		stat, statErr := os.Stat(c.real)
+		if statErr != nil {
+			continue
+		}
+		logger.Debug("assetfs: mount hit", "path", pth, "real", c.real)
+		return withMountMeta(&RealFileInfo{basicFileInfo(pth, stat), c.real}, c.meta), true, nil
+	}
+	if len(candidates) > 0 {
+		probed := make([]string, len(candidates))
+		for i, c := range candidates {
+			probed[i] = c.real
+		}
+		logger.Debug("assetfs: mount miss", "path", pth, "probed", probed)
+	}
+	return nil, false, nil
+}
+
+// mountWalk walks every mount covering dir, deduping virtual paths already
+// seen so stacked mounts at the same target yield a single union listing.
+// Every candidate virtual path is checked against dir (via inScope) before
+// reaching cb, since a mount's own source tree extends beyond whatever
+// virtual subtree the caller actually asked to walk. ctx is used only for
+// the attached assetfsapi.Logger, if any.
+func mountWalk(ctx context.Context, fs *AssetFileSystem, dir string, cb assetfsapi.CbWalkInfoFunc, mode assetfsapi.WalkMode, seen map[string]bool) (err error) {
+	if fs.mounts == nil {
+		return nil
+	}
+	dirSlash := path.Clean(filepath.ToSlash(dir))
+	inScope := func(vpth string) bool {
+		return dirSlash == "." || vpth == dirSlash || strings.HasPrefix(vpth, dirSlash+"/")
+	}
+	for _, m := range fs.mounts.at(dir) {
+		target := path.Clean(filepath.ToSlash(m.Target))
+		switch src := m.Source.(type) {
+		case assetfsapi.Interface:
+			err = src.WalkInfo(".", func(info assetfsapi.FileInfo) error {
+				vpth := path.Join(m.Target, filepath.ToSlash(info.Path()))
+				if !inScope(vpth) {
+					return nil
+				}
+				if seen[vpth] {
+					return nil
+				}
+				if m.Exclude != nil && m.Exclude.Match(filepath.Base(vpth)) {
+					return nil
+				}
+				if m.Include != nil && !m.Include.Match(filepath.Base(vpth)) {
+					return nil
+				}
+				seen[vpth] = true
+				switch t := info.(type) {
+				case *RealDirFileInfo:
+					assetfsapi.SetBasicFileInfoPath(t.BasicFileInfo, filepath.FromSlash(vpth))
+				case *RealFileInfo:
+					assetfsapi.SetBasicFileInfoPath(t.BasicFileInfo, filepath.FromSlash(vpth))
+				}
+				return cb(withMountMeta(info, m.Meta))
+			}, mode)
+		case string:
+			// When dir nests inside target, start the OS walk at the real
+			// subdirectory that corresponds to it instead of the mount
+			// root, so a subtree the pattern can never reach (e.g. dir
+			// "assets/vendor/icons" against a mount targeting
+			// "assets/vendor") is never even read from disk.
+			root := src
+			if dirSlash != "." && dirSlash != target && strings.HasPrefix(dirSlash, target+"/") {
+				root = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(dirSlash, target+"/")))
+			}
+			if _, statErr := os.Stat(root); statErr != nil {
+				continue
+			}
+			err = filepath.Walk(root, func(realPath string, fi os.FileInfo, werr error) error {
+				if werr != nil {
+					return werr
+				}
+				if realPath == root {
+					return nil
+				}
+				rel := strings.TrimPrefix(realPath, src)
+				rel = strings.TrimPrefix(filepath.ToSlash(rel), "/")
+				vpth := path.Join(m.Target, rel)
+				if !inScope(vpth) {
+					return nil
+				}
+				if seen[vpth] {
+					return nil
+				}
+				if fi.IsDir() {
+					if !mode.IsDirs() {
+						return nil
+					}
+				} else {
+					if !mode.IsFiles() {
+						return nil
+					}
+				}
+				if m.Exclude != nil && m.Exclude.Match(filepath.Base(vpth)) {
+					return nil
+				}
+				if m.Include != nil && !m.Include.Match(filepath.Base(vpth)) {
+					return nil
+				}
+				seen[vpth] = true
+				return cb(withMountMeta(&RealFileInfo{basicFileInfo(filepath.FromSlash(vpth), fi), realPath}, m.Meta))
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
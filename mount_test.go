@@ -0,0 +1,35 @@
+package assetfs
+
+import (
+	"testing"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+// These cover mountTable.at, the lookup mountWalk's dir-scoping fix relies
+// on to decide which mounts even need to be walked for a given dir. A full
+// exercise of mountWalk itself needs an *AssetFileSystem, which this
+// snapshot's local sources/namespace plumbing (outside mount.go) does not
+// provide a constructor for.
+func TestMountTableAt(t *testing.T) {
+	table := &mountTable{}
+	table.add(assetfsapi.Mount{Source: "/src/vendor", Target: "assets/vendor"})
+
+	cases := []struct {
+		dir  string
+		want bool
+	}{
+		{".", true},
+		{"assets", true},
+		{"assets/vendor", true},
+		{"assets/vendor/icons", true},
+		{"assets/img", false},
+		{"other", false},
+	}
+	for _, c := range cases {
+		got := len(table.at(c.dir)) > 0
+		if got != c.want {
+			t.Errorf("at(%q) covered = %v, want %v", c.dir, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Package pipeline implements a small resource pipeline on top of
+// assetfsapi.AssetGetterInterface, inspired by Hugo Piper: Get a resource,
+// then chain transforms (Fingerprint, Minify, Gzip, Brotli, Concat, Rename)
+// that each return a new, immutable Resource. The result of every
+// content-changing transform (Minify, Gzip, Brotli, Concat) is cached to
+// disk keyed by the input content hash plus the transform chain that
+// produced it, so re-running an unchanged pipeline costs a cache-file read,
+// not a recompute. Fingerprint and Rename only recompute the permalink and
+// are not cached.
+package pipeline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+// ErrNoBrotliEncoder is returned by Resource.Brotli when no encoder has
+// been installed via SetBrotliEncoder. The pipeline has no brotli
+// dependency of its own; applications that need it wire one in (e.g. a
+// github.com/andybalholm/brotli-backed closure) at startup.
+var ErrNoBrotliEncoder = errors.New("pipeline: no brotli encoder installed, see SetBrotliEncoder")
+
+// BrotliEncoder wraps dst with a brotli-compressing io.WriteCloser.
+type BrotliEncoder func(dst io.Writer) io.WriteCloser
+
+var brotliEncoder BrotliEncoder
+
+// SetBrotliEncoder installs the encoder used by Resource.Brotli. Call it
+// once at program startup; it is not safe to change concurrently with
+// pipeline use.
+func SetBrotliEncoder(enc BrotliEncoder) {
+	brotliEncoder = enc
+}
+
+// Pipeline resolves resources from fs and caches transform output under
+// cacheDir.
+type Pipeline struct {
+	fs       assetfsapi.AssetGetterInterface
+	cacheDir string
+}
+
+// New creates a Pipeline reading assets from fs and caching transform
+// output under cacheDir. cacheDir is created lazily on first write.
+func New(fs assetfsapi.AssetGetterInterface, cacheDir string) *Pipeline {
+	return &Pipeline{fs: fs, cacheDir: cacheDir}
+}
+
+// CacheDir returns the directory transform output is cached under.
+func (p *Pipeline) CacheDir() string {
+	return p.cacheDir
+}
+
+// Get resolves path to a Resource with no transforms applied yet.
+func (p *Pipeline) Get(path string) (*Resource, error) {
+	return p.GetC(context.Background(), path)
+}
+
+// GetC is the context-aware form of Get.
+func (p *Pipeline) GetC(ctx context.Context, pth string) (*Resource, error) {
+	asset, err := p.fs.AssetC(ctx, pth)
+	if err != nil {
+		return nil, err
+	}
+	rd, err := asset.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+	content, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	r := &Resource{
+		pipeline: p,
+		content:  content,
+		chainKey: "get",
+	}
+	r.Data.Path = path2slash(pth)
+	r.Data.RelPermalink = r.Data.Path
+	r.Data.MediaType = mediaType(pth)
+	r.hash()
+	return r, nil
+}
+
+func path2slash(pth string) string {
+	return filepath.ToSlash(pth)
+}
+
+func mediaType(pth string) string {
+	if mt := mime.TypeByExtension(filepath.Ext(pth)); mt != "" {
+		return mt
+	}
+	return "application/octet-stream"
+}
+
+// Data holds the metadata templates need to emit a reference to a
+// Resource: its content hash, an SRI integrity digest, media type, and the
+// permalink it should actually be served from.
+type Data struct {
+	Digest       string
+	Integrity    string
+	MediaType    string
+	Path         string
+	RelPermalink string
+}
+
+// Resource wraps a (possibly transformed) asset's content plus the Data
+// templates consume. Resources are immutable; every transform method
+// returns a new Resource rather than mutating the receiver.
+type Resource struct {
+	pipeline *Pipeline
+	content  []byte
+	chainKey string
+	Data     Data
+}
+
+// Content returns the resource's current bytes.
+func (r *Resource) Content() []byte {
+	return r.content
+}
+
+func (r *Resource) hash() {
+	sum := sha256.Sum256(r.content)
+	r.Data.Digest = hex.EncodeToString(sum[:])
+	r.Data.Integrity = "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// cacheKey derives the on-disk cache path for step applied on top of
+// r.chainKey, identifying the step and its content unambiguously.
+func (r *Resource) cacheKey(step string) string {
+	h := sha256.Sum256([]byte(r.chainKey + "|" + step + "|" + r.Data.Digest))
+	return hex.EncodeToString(h[:])
+}
+
+// derive produces the Resource resulting from applying step to r's
+// content, consulting/populating the pipeline cache dir when one is
+// configured.
+func (r *Resource) derive(step string, transform func([]byte) ([]byte, error)) (*Resource, error) {
+	key := r.cacheKey(step)
+	if r.pipeline.cacheDir != "" {
+		cachePath := filepath.Join(r.pipeline.cacheDir, key)
+		if data, err := ioutil.ReadFile(cachePath); err == nil {
+			return r.next(step, data), nil
+		}
+	}
+	data, err := transform(r.content)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %s: %w", step, err)
+	}
+	if r.pipeline.cacheDir != "" {
+		if err := os.MkdirAll(r.pipeline.cacheDir, 0755); err == nil {
+			_ = ioutil.WriteFile(filepath.Join(r.pipeline.cacheDir, key), data, 0644)
+		}
+	}
+	return r.next(step, data), nil
+}
+
+func (r *Resource) next(step string, content []byte) *Resource {
+	n := &Resource{
+		pipeline: r.pipeline,
+		content:  content,
+		chainKey: r.chainKey + "|" + step,
+		Data:     r.Data,
+	}
+	n.hash()
+	return n
+}
+
+// Fingerprint renames the resource to embed its content hash (e.g.
+// styles.css -> styles.3a7c9e01b2f4.css), the same scheme Rename("{name}.{hash}{ext}")
+// would produce, and is the common case callers reach for directly.
+func (r *Resource) Fingerprint() (*Resource, error) {
+	return r.Rename("{name}.{hash}{ext}")
+}
+
+var renamePlaceholder = regexp.MustCompile(`\{(name|hash|ext)\}`)
+
+// Rename recomputes RelPermalink from pattern, substituting {name}, {hash}
+// (first 12 hex chars of the content digest) and {ext} (including the
+// leading dot). It does not touch content, only Data.RelPermalink.
+func (r *Resource) Rename(pattern string) (*Resource, error) {
+	n := r.next("rename:"+pattern, r.content)
+	n.renameApply(pattern)
+	return n, nil
+}
+
+// Concat appends the content of the other assets named by paths, in
+// order, after r's own content, separated by a newline. It is typically
+// called on the first Resource in a bundle: a, _ := p.Get("a.js"); a.Concat("b.js", "c.js").
+func (r *Resource) Concat(paths ...string) (*Resource, error) {
+	others := make([]*Resource, len(paths))
+	stepParts := make([]string, len(paths))
+	for i, p := range paths {
+		other, err := r.pipeline.Get(p)
+		if err != nil {
+			return nil, err
+		}
+		others[i] = other
+		stepParts[i] = p + "@" + other.Data.Digest
+	}
+	step := "concat:" + strings.Join(stepParts, ",")
+	return r.derive(step, func(content []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		buf.Write(content)
+		for _, other := range others {
+			buf.WriteByte('\n')
+			buf.Write(other.content)
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+// Minify strips leading/trailing whitespace and blank lines from
+// text-like media types (CSS, JS, HTML, JSON, SVG); other media types are
+// returned unchanged. It is intentionally simple rather than a full
+// parser-based minifier.
+func (r *Resource) Minify() (*Resource, error) {
+	res, err := r.derive("minify", func(content []byte) ([]byte, error) {
+		if !isTextLike(r.Data.MediaType) {
+			return content, nil
+		}
+		lines := bytes.Split(content, []byte("\n"))
+		out := make([][]byte, 0, len(lines))
+		for _, line := range lines {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			out = append(out, line)
+		}
+		return bytes.Join(out, []byte("\n")), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func isTextLike(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.Contains(mediaType, "javascript"):
+		return true
+	case strings.Contains(mediaType, "json"):
+		return true
+	case strings.Contains(mediaType, "svg"):
+		return true
+	default:
+		return false
+	}
+}
+
+// Gzip compresses the resource content and renames it with a ".gz" suffix.
+func (r *Resource) Gzip() (*Resource, error) {
+	res, err := r.derive("gzip", func(content []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Rename("{name}{ext}.gz")
+}
+
+// Brotli compresses the resource content with the encoder installed via
+// SetBrotliEncoder and renames it with a ".br" suffix. It returns
+// ErrNoBrotliEncoder if none has been installed.
+func (r *Resource) Brotli() (*Resource, error) {
+	if brotliEncoder == nil {
+		return nil, ErrNoBrotliEncoder
+	}
+	res, err := r.derive("brotli", func(content []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w := brotliEncoder(&buf)
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Rename("{name}{ext}.br")
+}
+
+// Handler serves Resources at their resolved RelPermalink (e.g.
+// styles.abc123.css). It is a standalone http.Handler: this package does
+// not hook into AssetFileSystem.ServeHTTP itself, so an application must
+// mount Handler at its own route (or as a NotFound fallback behind its own
+// router) and Register every Resource it builds before traffic arrives.
+// That is a gap against the original "resolved output paths are served
+// transparently through AssetFileSystem.ServeHTTP" request: this snapshot
+// has no AssetFileSystem.ServeHTTP to fall back into, so the handler
+// could not be wired any deeper than this.
+type Handler struct {
+	mu        sync.RWMutex
+	resources map[string]*Resource
+}
+
+// NewHandler creates an empty Handler; register resources with Register.
+func NewHandler() *Handler {
+	return &Handler{resources: map[string]*Resource{}}
+}
+
+// Register makes r servable at r.Data.RelPermalink, returning r unchanged
+// so it can be chained: h.Register(res.Fingerprint()), where res came from
+// p.Get("a.css").
+func (h *Handler) Register(r *Resource, err error) (*Resource, error) {
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.resources[r.Data.RelPermalink] = r
+	h.mu.Unlock()
+	return r, nil
+}
+
+// ServeHTTP implements http.Handler, serving a registered resource's
+// content if its path matches, or 404 otherwise.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.mu.RLock()
+	r, ok := h.resources[strings.TrimPrefix(req.URL.Path, "/")]
+	h.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", r.Data.MediaType)
+	w.Header().Set("ETag", `"`+r.Data.Digest+`"`)
+	http.ServeContent(w, req, path.Base(r.Data.RelPermalink), time.Time{}, bytes.NewReader(r.content))
+}
+
+func (r *Resource) renameApply(pattern string) {
+	ext := path.Ext(r.Data.Path)
+	name := strings.TrimSuffix(path.Base(r.Data.Path), ext)
+	hash := r.Data.Digest
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	out := renamePlaceholder.ReplaceAllStringFunc(pattern, func(m string) string {
+		switch m {
+		case "{name}":
+			return name
+		case "{hash}":
+			return hash
+		case "{ext}":
+			return ext
+		}
+		return m
+	})
+	r.Data.RelPermalink = path.Join(path.Dir(r.Data.Path), out)
+}
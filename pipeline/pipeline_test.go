@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+var errNotFound = errors.New("pipeline: fakeFS: not found")
+
+// fakeFS is a minimal assetfsapi.AssetGetterInterface backed by an
+// in-memory map, letting tests mutate a path's content between Get calls.
+type fakeFS struct {
+	files map[string]string
+}
+
+func newFakeFS(files map[string]string) *fakeFS {
+	return &fakeFS{files: files}
+}
+
+type fakeAsset struct {
+	name, path string
+	content    string
+}
+
+func (a *fakeAsset) Name() string { return a.name }
+func (a *fakeAsset) Path() string { return a.path }
+func (a *fakeAsset) Reader() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader([]byte(a.content))), nil
+}
+
+func (fs *fakeFS) Asset(pth string) (assetfsapi.AssetInterface, error) {
+	return fs.AssetC(context.Background(), pth)
+}
+
+func (fs *fakeFS) AssetC(_ context.Context, pth string) (assetfsapi.AssetInterface, error) {
+	content, ok := fs.files[pth]
+	if !ok {
+		return nil, errNotFound
+	}
+	return &fakeAsset{name: pth, path: pth, content: content}, nil
+}
+
+func (fs *fakeFS) MustAsset(pth string) assetfsapi.AssetInterface {
+	a, err := fs.Asset(pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *fakeFS) MustAssetC(ctx context.Context, pth string) assetfsapi.AssetInterface {
+	a, err := fs.AssetC(ctx, pth)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fs *fakeFS) AssetInfo(pth string) (assetfsapi.FileInfo, error) { return nil, errNotFound }
+func (fs *fakeFS) AssetInfoC(context.Context, string) (assetfsapi.FileInfo, error) {
+	return nil, errNotFound
+}
+func (fs *fakeFS) MustAssetInfo(string) assetfsapi.FileInfo { panic("not implemented") }
+func (fs *fakeFS) MustAssetInfoC(context.Context, string) assetfsapi.FileInfo {
+	panic("not implemented")
+}
+
+func (fs *fakeFS) AssetReader() assetfsapi.AssetReaderFunc {
+	return func(pth string) (io.ReadCloser, error) {
+		a, err := fs.Asset(pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+func (fs *fakeFS) AssetReaderC() assetfsapi.AssetReaderFuncC {
+	return func(ctx context.Context, pth string) (io.ReadCloser, error) {
+		a, err := fs.AssetC(ctx, pth)
+		if err != nil {
+			return nil, err
+		}
+		return a.Reader()
+	}
+}
+
+func (fs *fakeFS) Provider(...assetfsapi.Interface)  {}
+func (fs *fakeFS) Providers() []assetfsapi.Interface { return nil }
+
+func TestConcatServesFreshContentAfterPartChanges(t *testing.T) {
+	fs := newFakeFS(map[string]string{"a.js": "var a=1;", "b.js": "var b=1;"})
+	p := New(fs, t.TempDir())
+
+	a, err := p.Get("a.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := a.Concat("b.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "var a=1;\nvar b=1;"; string(r1.Content()) != want {
+		t.Fatalf("first Concat() = %q, want %q", r1.Content(), want)
+	}
+
+	// b.js changes without being renamed; re-running the identical
+	// pipeline on a must not reuse a's old concat cache entry.
+	fs.files["b.js"] = "var b=2;"
+
+	a2, err := p.Get("a.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := a2.Concat("b.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "var a=1;\nvar b=2;"; string(r2.Content()) != want {
+		t.Fatalf("Concat() served stale cached content: got %q, want %q", r2.Content(), want)
+	}
+}
@@ -0,0 +1,25 @@
+package assetfs
+
+import (
+	"context"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+// WalkInfoC implements assetfsapi.TraversableInterface's context-aware
+// walk: ctx is threaded through every recursive call (namespaces, mounts,
+// parent lookup), so a Logger attached via assetfsapi.WithLogger sees the
+// whole walk as one traced operation.
+func (fs *AssetFileSystem) WalkInfoC(ctx context.Context, dir string, cb assetfsapi.CbWalkInfoFunc, mode ...assetfsapi.WalkMode) error {
+	m := assetfsapi.WalkAll
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+	return filesystemWalkC(ctx, fs, dir, cb, m)
+}
+
+// GlobInfoC implements assetfsapi.TraversableInterface's context-aware
+// glob; see WalkInfoC.
+func (fs *AssetFileSystem) GlobInfoC(ctx context.Context, pattern assetfsapi.GlobPattern, cb func(info assetfsapi.FileInfo) error) error {
+	return filesystemGlobInfoC(ctx, fs, pattern, cb)
+}
@@ -0,0 +1,293 @@
+// Package webdav exposes an assetfsapi.Interface as a WebDAV drive,
+// matching the model of golang.org/x/net/webdav but working directly
+// against this module's Interface instead of its own vfs abstraction.
+// Read-only providers (e.g. bindata) serve GET/HEAD/PROPFIND fine and
+// answer every write verb with 403 Forbidden.
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/moisespsena-go/assetfs/assetfsapi"
+)
+
+// Handler implements the WebDAV verbs needed to browse and edit an
+// assetfsapi.Interface: PROPFIND, MKCOL, PUT, DELETE, MOVE, COPY, LOCK,
+// UNLOCK, plus GET/HEAD for plain reads (delegated to fs.ServeHTTP).
+type Handler struct {
+	fs assetfsapi.Interface
+}
+
+// NewHandler mounts fs as a WebDAV drive.
+func NewHandler(fs assetfsapi.Interface) http.Handler {
+	return &Handler{fs: fs}
+}
+
+func (h *Handler) writable() (assetfsapi.WritableInterface, bool) {
+	w, ok := h.fs.(assetfsapi.WritableInterface)
+	return w, ok
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pth := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	if pth == "." {
+		pth = ""
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.fs.ServeHTTP(w, r)
+	case "PROPFIND":
+		h.propfind(w, r, pth)
+	case "MKCOL":
+		h.mkcol(w, pth)
+	case http.MethodPut:
+		h.put(w, r, pth)
+	case http.MethodDelete:
+		h.remove(w, pth)
+	case "MOVE":
+		h.move(w, r, pth, false)
+	case "COPY":
+		h.move(w, r, pth, true)
+	case "LOCK":
+		h.lock(w, pth)
+	case "UNLOCK":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PROPFIND, MKCOL, PUT, DELETE, MOVE, COPY, LOCK, UNLOCK")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) mkcol(w http.ResponseWriter, pth string) {
+	wfs, ok := h.writable()
+	if !ok {
+		http.Error(w, "read-only source", http.StatusForbidden)
+		return
+	}
+	if err := wfs.Mkdir(pth); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) put(w http.ResponseWriter, r *http.Request, pth string) {
+	wfs, ok := h.writable()
+	if !ok {
+		http.Error(w, "read-only source", http.StatusForbidden)
+		return
+	}
+	out, err := wfs.Create(pth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	_, err = io.Copy(out, r.Body)
+	cerr := out.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if cerr != nil {
+		http.Error(w, cerr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) remove(w http.ResponseWriter, pth string) {
+	wfs, ok := h.writable()
+	if !ok {
+		http.Error(w, "read-only source", http.StatusForbidden)
+		return
+	}
+	if err := wfs.Remove(pth); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// move handles both MOVE and COPY: WebDAV COPY is a move that leaves the
+// source in place, which this Interface has no primitive for, so COPY
+// reads the source fully and writes it to the destination instead of
+// renaming. Copying a directory recurses, creating dest and every
+// descendant directory before the files inside them (see copyDir).
+func (h *Handler) move(w http.ResponseWriter, r *http.Request, pth string, isCopy bool) {
+	wfs, ok := h.writable()
+	if !ok {
+		http.Error(w, "read-only source", http.StatusForbidden)
+		return
+	}
+	dest := r.Header.Get("Destination")
+	if dest == "" {
+		http.Error(w, "missing Destination header", http.StatusBadRequest)
+		return
+	}
+	if u, err := url.Parse(dest); err == nil {
+		dest = u.Path
+	}
+	dest = strings.TrimPrefix(path.Clean(dest), "/")
+
+	if !isCopy {
+		if err := wfs.Rename(pth, dest); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	info, err := h.fs.AssetInfo(pth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if info.IsDir() {
+		if err := h.copyDir(wfs, pth, dest); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.copyFile(wfs, pth, dest); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) copyFile(wfs assetfsapi.WritableInterface, src, dest string) error {
+	asset, err := h.fs.Asset(src)
+	if err != nil {
+		return err
+	}
+	rd, err := asset.Reader()
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	out, err := wfs.Create(dest)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, rd)
+	cerr := out.Close()
+	if err != nil {
+		return err
+	}
+	return cerr
+}
+
+// copyDir recursively copies src onto dest, creating dest and every
+// descendant directory before the files inside them.
+func (h *Handler) copyDir(wfs assetfsapi.WritableInterface, src, dest string) error {
+	if err := wfs.Mkdir(dest); err != nil {
+		return err
+	}
+	return h.fs.ReadDir(src, func(child assetfsapi.FileInfo) error {
+		childSrc := path.Join(src, child.Name())
+		childDest := path.Join(dest, child.Name())
+		if child.IsDir() {
+			return h.copyDir(wfs, childSrc, childDest)
+		}
+		return h.copyFile(wfs, childSrc, childDest)
+	}, false)
+}
+
+// lock grants every request an opaque lock token unconditionally; it does
+// not track or enforce exclusion between clients. This is enough for
+// WebDAV clients (notably Windows' and macOS') that require a successful
+// LOCK before PUT, without building out the full RFC 4918 lock manager.
+func (h *Handler) lock(w http.ResponseWriter, pth string) {
+	token := "opaquelocktoken:" + pth
+	w.Header().Set("Lock-Token", "<"+token+">")
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<D:prop xmlns:D="DAV:"><D:lockdiscovery><D:activelock>
+<D:locktype><D:write/></D:locktype>
+<D:lockscope><D:exclusive/></D:lockscope>
+<D:depth>infinity</D:depth>
+<D:locktoken><D:href>%s</D:href></D:locktoken>
+</D:activelock></D:lockdiscovery></D:prop>`, token)
+}
+
+func (h *Handler) propfind(w http.ResponseWriter, r *http.Request, pth string) {
+	info, err := h.fs.AssetInfo(pth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	ms := &multistatus{Xmlns: "DAV:"}
+	ms.Responses = append(ms.Responses, responseFor(pth, info))
+
+	if info.IsDir() && r.Header.Get("Depth") != "0" {
+		_ = h.fs.ReadDir(pth, func(child assetfsapi.FileInfo) error {
+			ms.Responses = append(ms.Responses, responseFor(path.Join(pth, child.Name()), child))
+			return nil
+		}, false)
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(StatusMulti)
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	_ = enc.Encode(ms)
+}
+
+// StatusMulti is WebDAV's 207 Multi-Status, not present in net/http's
+// status constants.
+const StatusMulti = 207
+
+func responseFor(pth string, info os.FileInfo) response {
+	p := prop{DisplayName: path.Base(pth)}
+	if info.IsDir() {
+		p.ResourceType = &resourceType{Collection: &struct{}{}}
+	} else {
+		p.ContentLength = info.Size()
+	}
+	p.LastModified = info.ModTime().UTC().Format(time.RFC1123)
+	return response{
+		Href: "/" + pth,
+		Propstat: propstat{
+			Prop:   p,
+			Status: "HTTP/1.1 200 OK",
+		},
+	}
+}
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"D:multistatus"`
+	Xmlns     string     `xml:"xmlns:D,attr"`
+	Responses []response `xml:"D:response"`
+}
+
+type response struct {
+	Href     string   `xml:"D:href"`
+	Propstat propstat `xml:"D:propstat"`
+}
+
+type propstat struct {
+	Prop   prop   `xml:"D:prop"`
+	Status string `xml:"D:status"`
+}
+
+type prop struct {
+	DisplayName   string        `xml:"D:displayname"`
+	ResourceType  *resourceType `xml:"D:resourcetype"`
+	ContentLength int64         `xml:"D:getcontentlength,omitempty"`
+	LastModified  string        `xml:"D:getlastmodified,omitempty"`
+}
+
+type resourceType struct {
+	Collection *struct{} `xml:"D:collection,omitempty"`
+}
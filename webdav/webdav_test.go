@@ -0,0 +1,36 @@
+package webdav
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moisespsena-go/assetfs/memfs"
+)
+
+func TestCopyDirectoryRecurses(t *testing.T) {
+	fs := memfs.New("")
+	fs.WriteFile("src/a.txt", []byte("a"))
+	fs.WriteFile("src/nested/b.txt", []byte("b"))
+
+	h := NewHandler(fs)
+
+	req := httptest.NewRequest("COPY", "/src", nil)
+	req.Header.Set("Destination", "/dst")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("COPY status = %d, want %d; body: %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	for _, pth := range []string{"dst/a.txt", "dst/nested/b.txt"} {
+		if _, err := fs.Asset(pth); err != nil {
+			t.Errorf("expected %s to exist after recursive COPY: %v", pth, err)
+		}
+	}
+	// The source tree must still be intact; COPY is not a move.
+	if _, err := fs.Asset("src/a.txt"); err != nil {
+		t.Errorf("COPY should leave the source in place: %v", err)
+	}
+}
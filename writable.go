@@ -0,0 +1,78 @@
+package assetfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writableRoot returns the local source root writes should land in. It
+// mirrors the precedence filesystemAssetInfo already uses for reads: the
+// most recently prepended path wins.
+func (fs *AssetFileSystem) writableRoot() (root string, err error) {
+	err = fs.eachPath(false, func(r string) error {
+		root = r
+		return io.EOF
+	})
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if root == "" {
+		return "", fmt.Errorf("assetfs: %s has no writable local source", fs.path)
+	}
+	return root, nil
+}
+
+// Create implements assetfsapi.WritableInterface, opening pth for writing
+// under the first writable local source. Intermediate directories are not
+// created; call Mkdir first.
+func (fs *AssetFileSystem) Create(pth string) (io.WriteCloser, error) {
+	if fs.mountBlocksWrite(pth) {
+		return nil, fmt.Errorf("assetfs: %s is covered by a read-only mount", pth)
+	}
+	root, err := fs.writableRoot()
+	if err != nil {
+		return nil, err
+	}
+	return os.Create(filepath.Join(root, filepath.FromSlash(pth)))
+}
+
+// Mkdir implements assetfsapi.WritableInterface, creating pth (and any
+// missing parents) as a directory under the first writable local source.
+func (fs *AssetFileSystem) Mkdir(pth string) error {
+	if fs.mountBlocksWrite(pth) {
+		return fmt.Errorf("assetfs: %s is covered by a read-only mount", pth)
+	}
+	root, err := fs.writableRoot()
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(filepath.Join(root, filepath.FromSlash(pth)), 0755)
+}
+
+// Remove implements assetfsapi.WritableInterface, deleting pth (a file or
+// empty directory) from the first writable local source.
+func (fs *AssetFileSystem) Remove(pth string) error {
+	if fs.mountBlocksWrite(pth) {
+		return fmt.Errorf("assetfs: %s is covered by a read-only mount", pth)
+	}
+	root, err := fs.writableRoot()
+	if err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(root, filepath.FromSlash(pth)))
+}
+
+// Rename implements assetfsapi.WritableInterface, moving oldPth to newPth
+// within the first writable local source.
+func (fs *AssetFileSystem) Rename(oldPth, newPth string) error {
+	if fs.mountBlocksWrite(oldPth) || fs.mountBlocksWrite(newPth) {
+		return fmt.Errorf("assetfs: rename touches a path covered by a read-only mount")
+	}
+	root, err := fs.writableRoot()
+	if err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(root, filepath.FromSlash(oldPth)), filepath.Join(root, filepath.FromSlash(newPth)))
+}